@@ -0,0 +1,166 @@
+// Package bench is a small statistical benchmarking harness for the Go
+// baseline programs under research/explorations/baselines. It exists
+// because a single hand-timed loop (one "per call" number) is too noisy
+// to compare across ilo-lang backends; Run instead calibrates a batch
+// size the way testing.B does, then reports a distribution over many
+// repetitions.
+package bench
+
+import (
+	"math"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// Options controls how a benchmark is calibrated and measured.
+type Options struct {
+	// Count is the number of timed repetitions to collect after
+	// calibration and warmup. Each repetition reports one ns/op sample.
+	Count int
+	// BenchTime is the target wall-clock duration for each timed
+	// repetition; it drives how many inner iterations are batched
+	// together so timer overhead stays negligible.
+	BenchTime time.Duration
+	// Warmup is how long to run fn before measurement begins, to let
+	// caches and branch predictors settle.
+	Warmup time.Duration
+}
+
+// DefaultOptions mirrors the defaults exposed by the -count, -benchtime
+// and -warmup flags.
+var DefaultOptions = Options{
+	Count:     20,
+	BenchTime: 100 * time.Millisecond,
+	Warmup:    250 * time.Millisecond,
+}
+
+// Result is the distribution of per-call latencies, in nanoseconds,
+// collected for a single named benchmark, plus the allocation profile
+// and when the run happened so results can be diffed across commits.
+type Result struct {
+	Name       string
+	N          int // number of timed repetitions
+	Iterations int // total calls to fn across all repetitions (inner * N)
+	Min        float64
+	Max        float64
+	Mean       float64
+	Median     float64
+	P90        float64
+	P99        float64
+	StdDev     float64
+	BytesOp    float64 // bytes allocated per call, via runtime.MemStats deltas
+	AllocsOp   float64 // allocations per call, via runtime.MemStats deltas
+	Timestamp  time.Time
+}
+
+// Run calibrates fn's batch size, warms it up, then times opts.Count
+// repetitions and returns the resulting ns/op distribution. opts.Count
+// is clamped to at least 1, since a distribution needs at least one
+// sample.
+func Run(name string, fn func(), opts Options) Result {
+	if opts.Count < 1 {
+		opts.Count = 1
+	}
+
+	inner := calibrate(fn, opts.BenchTime)
+	warmup(fn, inner, opts.Warmup)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	samples := make([]float64, opts.Count)
+	for i := 0; i < opts.Count; i++ {
+		start := time.Now()
+		for j := 0; j < inner; j++ {
+			fn()
+		}
+		elapsed := time.Since(start)
+		samples[i] = float64(elapsed.Nanoseconds()) / float64(inner)
+	}
+
+	runtime.ReadMemStats(&after)
+	totalCalls := float64(inner * opts.Count)
+
+	result := summarize(name, samples)
+	result.Iterations = inner * opts.Count
+	result.BytesOp = float64(after.TotalAlloc-before.TotalAlloc) / totalCalls
+	result.AllocsOp = float64(after.Mallocs-before.Mallocs) / totalCalls
+	result.Timestamp = time.Now()
+	return result
+}
+
+// calibrate grows the inner iteration count until a single batch takes
+// at least benchTime, so per-repetition measurements aren't dominated
+// by time.Now overhead.
+func calibrate(fn func(), benchTime time.Duration) int {
+	inner := 1
+	for {
+		start := time.Now()
+		for i := 0; i < inner; i++ {
+			fn()
+		}
+		if time.Since(start) >= benchTime {
+			return inner
+		}
+		inner *= 2
+	}
+}
+
+// warmup runs fn in inner-sized batches until warmupTime has elapsed.
+func warmup(fn func(), inner int, warmupTime time.Duration) {
+	start := time.Now()
+	for time.Since(start) < warmupTime {
+		for i := 0; i < inner; i++ {
+			fn()
+		}
+	}
+}
+
+func summarize(name string, samples []float64) Result {
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	var sum float64
+	for _, s := range sorted {
+		sum += s
+	}
+	mean := sum / float64(n)
+
+	var variance float64
+	for _, s := range sorted {
+		d := s - mean
+		variance += d * d
+	}
+	variance /= float64(n)
+
+	return Result{
+		Name:   name,
+		N:      n,
+		Min:    sorted[0],
+		Max:    sorted[n-1],
+		Mean:   mean,
+		Median: percentile(sorted, 0.5),
+		P90:    percentile(sorted, 0.9),
+		P99:    percentile(sorted, 0.99),
+		StdDev: math.Sqrt(variance),
+	}
+}
+
+// percentile returns the p-th percentile (0 <= p <= 1) of an
+// already-sorted slice, using linear interpolation between the two
+// closest samples.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return sorted[lo]
+	}
+	frac := rank - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac
+}
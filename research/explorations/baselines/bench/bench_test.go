@@ -0,0 +1,109 @@
+package bench
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{"single sample", []float64{5}, 0.5, 5},
+		{"single sample p99", []float64{5}, 0.99, 5},
+		{"median of odd length", []float64{1, 2, 3, 4, 5}, 0.5, 3},
+		{"median of even length interpolates", []float64{1, 2, 3, 4}, 0.5, 2.5},
+		{"p0 is min", []float64{1, 2, 3, 4, 5}, 0, 1},
+		{"p100 is max", []float64{1, 2, 3, 4, 5}, 1, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := percentile(tt.sorted, tt.p)
+			if got != tt.want {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	samples := []float64{1, 2, 3, 4, 5}
+	r := summarize("tot", samples)
+
+	if r.Name != "tot" {
+		t.Errorf("Name = %q, want %q", r.Name, "tot")
+	}
+	if r.N != 5 {
+		t.Errorf("N = %d, want 5", r.N)
+	}
+	if r.Min != 1 {
+		t.Errorf("Min = %v, want 1", r.Min)
+	}
+	if r.Max != 5 {
+		t.Errorf("Max = %v, want 5", r.Max)
+	}
+	if r.Mean != 3 {
+		t.Errorf("Mean = %v, want 3", r.Mean)
+	}
+	if r.Median != 3 {
+		t.Errorf("Median = %v, want 3", r.Median)
+	}
+	wantStdDev := math.Sqrt(2) // population stddev of 1..5
+	if math.Abs(r.StdDev-wantStdDev) > 1e-9 {
+		t.Errorf("StdDev = %v, want %v", r.StdDev, wantStdDev)
+	}
+}
+
+func TestSummarizeDoesNotMutateInput(t *testing.T) {
+	samples := []float64{5, 3, 1, 4, 2}
+	original := append([]float64(nil), samples...)
+	summarize("tot", samples)
+	for i := range samples {
+		if samples[i] != original[i] {
+			t.Fatalf("summarize mutated its input: got %v, want %v", samples, original)
+		}
+	}
+}
+
+func TestCalibrateGrowsUntilBenchTimeElapses(t *testing.T) {
+	calls := 0
+	fn := func() { calls++ }
+
+	inner := calibrate(fn, 10*time.Millisecond)
+
+	if inner < 1 {
+		t.Fatalf("calibrate returned %d, want >= 1", inner)
+	}
+	if calls < inner {
+		t.Fatalf("fn called %d times, want at least inner=%d", calls, inner)
+	}
+}
+
+func TestRunClampsNonPositiveCount(t *testing.T) {
+	opts := Options{Count: 0, BenchTime: time.Millisecond, Warmup: 0}
+	r := Run("tot", func() {}, opts)
+	if r.N != 1 {
+		t.Errorf("N = %d, want 1 when Options.Count is 0", r.N)
+	}
+
+	opts.Count = -5
+	r = Run("tot", func() {}, opts)
+	if r.N != 1 {
+		t.Errorf("N = %d, want 1 when Options.Count is negative", r.N)
+	}
+}
+
+func TestRunReportsTotalIterations(t *testing.T) {
+	opts := Options{Count: 4, BenchTime: time.Millisecond, Warmup: 0}
+	r := Run("tot", func() {}, opts)
+	if r.Iterations < r.N {
+		t.Errorf("Iterations = %d, want at least N = %d", r.Iterations, r.N)
+	}
+	if r.Iterations%r.N != 0 {
+		t.Errorf("Iterations = %d is not a multiple of N = %d", r.Iterations, r.N)
+	}
+}
@@ -0,0 +1,142 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+)
+
+// Format names the supported output encodings for a slice of Results.
+type Format string
+
+const (
+	Text      Format = "text"
+	JSON      Format = "json"
+	CSV       Format = "csv"
+	Benchstat Format = "benchstat"
+)
+
+// Formats lists every Format accepted by Write, for flag validation and
+// usage text.
+var Formats = []Format{Text, JSON, CSV, Benchstat}
+
+// Write encodes results to w in the given format. An unrecognized
+// format is an error rather than silently falling back to text, since a
+// typo there should be caught, not produce a surprising report.
+func Write(w io.Writer, format Format, results []Result) error {
+	switch format {
+	case Text:
+		return writeText(w, results)
+	case JSON:
+		return writeJSON(w, results)
+	case CSV:
+		return writeCSV(w, results)
+	case Benchstat:
+		return writeBenchstat(w, results)
+	default:
+		return fmt.Errorf("bench: unknown output format %q", format)
+	}
+}
+
+func writeText(w io.Writer, results []Result) error {
+	for _, r := range results {
+		_, err := fmt.Fprintf(w, "%s: n=%d min=%.0fns max=%.0fns mean=%.0fns median=%.0fns p90=%.0fns p99=%.0fns stddev=%.0fns bytes/op=%.1f allocs/op=%.1f\n",
+			r.Name, r.N, r.Min, r.Max, r.Mean, r.Median, r.P90, r.P99, r.StdDev, r.BytesOp, r.AllocsOp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonResult mirrors Result with an RFC 3339 timestamp, since
+// time.Time's default JSON encoding is already RFC 3339 but we want the
+// field named explicitly for consumers that don't parse Go's layout.
+type jsonResult struct {
+	Name      string  `json:"name"`
+	N         int     `json:"n"`
+	Iters     int     `json:"iterations"`
+	Min       float64 `json:"min_ns"`
+	Max       float64 `json:"max_ns"`
+	Mean      float64 `json:"mean_ns"`
+	Median    float64 `json:"median_ns"`
+	P90       float64 `json:"p90_ns"`
+	P99       float64 `json:"p99_ns"`
+	StdDev    float64 `json:"stddev_ns"`
+	BytesOp   float64 `json:"bytes_per_op"`
+	AllocsOp  float64 `json:"allocs_per_op"`
+	Timestamp string  `json:"timestamp"`
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	out := make([]jsonResult, len(results))
+	for i, r := range results {
+		out[i] = jsonResult{
+			Name: r.Name, N: r.N, Iters: r.Iterations,
+			Min: r.Min, Max: r.Max, Mean: r.Mean, Median: r.Median,
+			P90: r.P90, P99: r.P99, StdDev: r.StdDev,
+			BytesOp: r.BytesOp, AllocsOp: r.AllocsOp,
+			Timestamp: r.Timestamp.Format(time.RFC3339),
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+var csvHeader = []string{
+	"name", "n", "min_ns", "max_ns", "mean_ns", "median_ns", "p90_ns", "p99_ns",
+	"stddev_ns", "bytes_per_op", "allocs_per_op", "timestamp",
+}
+
+func writeCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.Name,
+			fmt.Sprintf("%d", r.N),
+			fmt.Sprintf("%g", r.Min),
+			fmt.Sprintf("%g", r.Max),
+			fmt.Sprintf("%g", r.Mean),
+			fmt.Sprintf("%g", r.Median),
+			fmt.Sprintf("%g", r.P90),
+			fmt.Sprintf("%g", r.P99),
+			fmt.Sprintf("%g", r.StdDev),
+			fmt.Sprintf("%g", r.BytesOp),
+			fmt.Sprintf("%g", r.AllocsOp),
+			r.Timestamp.Format(time.RFC3339),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeBenchstat emits the "BenchmarkName-N  iters  ns/op" line format
+// that golang.org/x/perf/cmd/benchstat expects, so ilo-lang's generated
+// code can be tracked with standard Go perf tooling. The "-N" suffix is
+// GOMAXPROCS, matching what `go test -bench` reports, and iters is the
+// total number of calls to fn (inner-loop iterations times
+// repetitions). The benchmark name carries only GOMAXPROCS, not the
+// repetition count, so benchstat treats repeated runs of the same
+// benchmark (e.g. at different -count values) as samples to diff
+// rather than as different benchmarks.
+func writeBenchstat(w io.Writer, results []Result) error {
+	procs := runtime.GOMAXPROCS(0)
+	for _, r := range results {
+		_, err := fmt.Fprintf(w, "Benchmark%s-%d\t%d\t%.2f ns/op\t%.2f B/op\t%.2f allocs/op\n",
+			r.Name, procs, r.Iterations, r.Mean, r.BytesOp, r.AllocsOp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
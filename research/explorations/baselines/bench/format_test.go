@@ -0,0 +1,127 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testResults() []Result {
+	return []Result{
+		{
+			Name: "tot", N: 3, Iterations: 3000,
+			Min: 1, Max: 5, Mean: 3, Median: 3, P90: 4, P99: 5, StdDev: 1.4,
+			BytesOp: 0, AllocsOp: 0,
+			Timestamp: time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, Format("xml"), testResults())
+	if err == nil {
+		t.Fatal("Write with an unknown format returned nil error, want one")
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Text, testResults()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "tot:") {
+		t.Errorf("text output %q missing benchmark name", out)
+	}
+	if !strings.Contains(out, "mean=3ns") {
+		t.Errorf("text output %q missing mean", out)
+	}
+}
+
+func TestWriteJSONRoundTrips(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JSON, testResults()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var decoded []jsonResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("got %d records, want 1", len(decoded))
+	}
+	if decoded[0].Name != "tot" || decoded[0].Mean != 3 {
+		t.Errorf("decoded = %+v, want name=tot mean_ns=3", decoded[0])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, testResults()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.ReadAll: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows (header + data), want 2", len(rows))
+	}
+	if rows[0][0] != "name" {
+		t.Errorf("header row = %v, want it to start with \"name\"", rows[0])
+	}
+	if rows[1][0] != "tot" {
+		t.Errorf("data row = %v, want it to start with \"tot\"", rows[1])
+	}
+}
+
+func TestWriteBenchstat(t *testing.T) {
+	results := testResults()
+	results[0].N = 20 // repetition count must not leak into the output
+	var buf bytes.Buffer
+	if err := Write(&buf, Benchstat, results); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	wantPrefix := "Benchmarktot-" + strconv.Itoa(runtime.GOMAXPROCS(0))
+	out := buf.String()
+	if !strings.HasPrefix(out, wantPrefix) {
+		t.Errorf("benchstat output %q does not start with %q", out, wantPrefix)
+	}
+	if strings.Contains(out, "tot-20") {
+		t.Errorf("benchstat output %q leaks the repetition count into the -N suffix", out)
+	}
+	if !strings.Contains(out, "3000\t") {
+		t.Errorf("benchstat output %q missing total iteration count 3000", out)
+	}
+}
+
+func TestWriteBenchstatStableAcrossRepeatCounts(t *testing.T) {
+	a := testResults()
+	a[0].N, a[0].Iterations = 20, 2000
+
+	b := testResults()
+	b[0].N, b[0].Iterations = 7, 2000
+
+	var bufA, bufB bytes.Buffer
+	if err := Write(&bufA, Benchstat, a); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := Write(&bufB, Benchstat, b); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	nameA := strings.SplitN(bufA.String(), "\t", 2)[0]
+	nameB := strings.SplitN(bufB.String(), "\t", 2)[0]
+	if nameA != nameB {
+		t.Errorf("same benchmark at different -count values produced different benchstat names %q vs %q", nameA, nameB)
+	}
+}
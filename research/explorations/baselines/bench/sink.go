@@ -0,0 +1,32 @@
+package bench
+
+// Sink and SinkFloat64 are package-level stores for benchmark results.
+// A result that's only read by fmt.Printf happens to keep today's
+// compiler from eliding the work that produced it, but that's an
+// accident of how main reads the variable, not a guarantee — a future
+// inliner could prove the value unused and remove the call it came
+// from. Benchmarks should instead call Store or StoreFloat64 inside
+// their hot loop, which is the supported pattern for writing ilo-lang
+// Go-backend micro-benchmarks.
+var Sink interface{}
+
+// SinkFloat64 is a typed alternative to Sink for float64 results, which
+// avoids the boxing allocation an interface{} assignment would add to
+// every iteration.
+var SinkFloat64 float64
+
+// Store assigns v to Sink. It is marked noinline so the store can't be
+// inlined away along with the call that produced v.
+//
+//go:noinline
+func Store(v interface{}) {
+	Sink = v
+}
+
+// StoreFloat64 assigns v to SinkFloat64. It is marked noinline for the
+// same reason as Store.
+//
+//go:noinline
+func StoreFloat64(v float64) {
+	SinkFloat64 = v
+}
@@ -0,0 +1,127 @@
+// Command ilo-bench runs the benchmarks listed in benchspec.toml
+// against every backend configured for them, parses each backend's JSON
+// output (see the bench package's Write), and prints a side-by-side
+// comparison table with each backend's ratio to the fastest.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"sort"
+	"text/tabwriter"
+)
+
+type backendResult struct {
+	MeanNS float64
+	Err    error
+}
+
+// jsonResult decodes the fields of bench.Write's JSON output that
+// ilo-bench needs; unrecognized fields are ignored by encoding/json.
+type jsonResult struct {
+	Name   string  `json:"name"`
+	MeanNS float64 `json:"mean_ns"`
+}
+
+func main() {
+	specPath := flag.String("spec", "benchspec.toml", "path to benchspec.toml")
+	flag.Parse()
+
+	f, err := os.Open(*specPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	benchmarks, err := parseSpec(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	for _, bm := range benchmarks {
+		fmt.Fprintf(w, "%s\t%s\n", bm.Name, bm.Description)
+		fmt.Fprintln(w, "backend\tns/op\tratio-to-fastest")
+
+		results := map[string]backendResult{}
+		for _, backend := range bm.Backends {
+			if backend.Run == "" {
+				continue
+			}
+			results[backend.Name] = runBackend(backend)
+		}
+
+		fastest := math.Inf(1)
+		for _, res := range results {
+			if res.Err == nil && res.MeanNS < fastest {
+				fastest = res.MeanNS
+			}
+		}
+
+		names := make([]string, 0, len(results))
+		for name := range results {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			res := results[name]
+			if res.Err != nil {
+				fmt.Fprintf(w, "%s\terror: %v\t-\n", name, res.Err)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%.1f\t%.2fx\n", name, res.MeanNS, res.MeanNS/fastest)
+		}
+
+		skipped := skippedBackends(bm.Backends)
+		if len(skipped) > 0 {
+			fmt.Fprintf(w, "(skipped, no run command configured: %v)\n", skipped)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
+
+func skippedBackends(backends []Backend) []string {
+	var skipped []string
+	for _, b := range backends {
+		if b.Run == "" {
+			skipped = append(skipped, b.Name)
+		}
+	}
+	return skipped
+}
+
+func runBackend(b Backend) backendResult {
+	if b.Build != "" {
+		if err := shell(b.Build).Run(); err != nil {
+			return backendResult{Err: fmt.Errorf("build: %w", err)}
+		}
+	}
+
+	out, err := shell(b.Run).Output()
+	if err != nil {
+		return backendResult{Err: fmt.Errorf("run: %w", err)}
+	}
+
+	var records []jsonResult
+	if err := json.Unmarshal(out, &records); err != nil {
+		return backendResult{Err: fmt.Errorf("parse output: %w", err)}
+	}
+	if len(records) == 0 {
+		return backendResult{Err: fmt.Errorf("run produced no results")}
+	}
+	return backendResult{MeanNS: records[0].MeanNS}
+}
+
+func shell(cmd string) *exec.Cmd {
+	c := exec.Command("sh", "-c", cmd)
+	c.Stderr = os.Stderr
+	return c
+}
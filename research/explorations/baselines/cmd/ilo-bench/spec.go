@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Backend is one [[benchmark.backend]] entry: how to build (optional)
+// and run a benchmark for a single ilo-lang backend or reference
+// implementation.
+type Backend struct {
+	Name  string
+	Build string
+	Run   string
+}
+
+// Benchmark is one [[benchmark]] entry: a named comparison and the
+// backends to run it against.
+type Benchmark struct {
+	Name        string
+	Description string
+	Backends    []Backend
+}
+
+// parseSpec reads benchspec.toml. It only understands the subset of
+// TOML this file's schema needs ([[benchmark]] and [[benchmark.backend]]
+// array-of-tables plus bare keys with quoted string values) — it is not
+// a general TOML parser.
+func parseSpec(r io.Reader) ([]Benchmark, error) {
+	var benchmarks []Benchmark
+	var section string // "" | "benchmark" | "backend"
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch line {
+		case "[[benchmark]]":
+			benchmarks = append(benchmarks, Benchmark{})
+			section = "benchmark"
+			continue
+		case "[[benchmark.backend]]":
+			if len(benchmarks) == 0 {
+				return nil, fmt.Errorf("benchspec: [[benchmark.backend]] before any [[benchmark]]")
+			}
+			b := &benchmarks[len(benchmarks)-1]
+			b.Backends = append(b.Backends, Backend{})
+			section = "backend"
+			continue
+		}
+
+		key, value, err := parseKV(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch section {
+		case "benchmark":
+			b := &benchmarks[len(benchmarks)-1]
+			switch key {
+			case "name":
+				b.Name = value
+			case "description":
+				b.Description = value
+			default:
+				return nil, fmt.Errorf("benchspec: unknown benchmark key %q", key)
+			}
+		case "backend":
+			b := &benchmarks[len(benchmarks)-1]
+			backend := &b.Backends[len(b.Backends)-1]
+			switch key {
+			case "name":
+				backend.Name = value
+			case "build":
+				backend.Build = value
+			case "run":
+				backend.Run = value
+			default:
+				return nil, fmt.Errorf("benchspec: unknown backend key %q", key)
+			}
+		default:
+			return nil, fmt.Errorf("benchspec: key %q outside any table", key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return benchmarks, nil
+}
+
+// parseKV splits a `key = "value"` line and unquotes value.
+func parseKV(line string) (key, value string, err error) {
+	idx := strings.Index(line, "=")
+	if idx < 0 {
+		return "", "", fmt.Errorf("benchspec: malformed line %q", line)
+	}
+	key = strings.TrimSpace(line[:idx])
+	raw := strings.TrimSpace(line[idx+1:])
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return "", "", fmt.Errorf("benchspec: value for %q must be a quoted string, got %q", key, raw)
+	}
+	return key, raw[1 : len(raw)-1], nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	input := `
+# a comment and a blank line above should be ignored
+
+[[benchmark]]
+name = "tot"
+description = "p*q + p*q*r"
+
+  [[benchmark.backend]]
+  name = "go"
+  build = "go build -o /tmp/x ."
+  run = "/tmp/x -output=json"
+
+  [[benchmark.backend]]
+  name = "c"
+  build = ""
+  run = ""
+
+[[benchmark]]
+name = "other"
+
+  [[benchmark.backend]]
+  name = "go"
+  build = ""
+  run = "echo hi"
+`
+	benchmarks, err := parseSpec(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseSpec: %v", err)
+	}
+	if len(benchmarks) != 2 {
+		t.Fatalf("got %d benchmarks, want 2", len(benchmarks))
+	}
+
+	tot := benchmarks[0]
+	if tot.Name != "tot" || tot.Description != "p*q + p*q*r" {
+		t.Errorf("benchmarks[0] = %+v, want name=tot description=\"p*q + p*q*r\"", tot)
+	}
+	if len(tot.Backends) != 2 {
+		t.Fatalf("got %d backends for tot, want 2", len(tot.Backends))
+	}
+	if got := tot.Backends[0]; got.Name != "go" || got.Build != "go build -o /tmp/x ." || got.Run != "/tmp/x -output=json" {
+		t.Errorf("tot.Backends[0] = %+v", got)
+	}
+	if got := tot.Backends[1]; got.Name != "c" || got.Build != "" || got.Run != "" {
+		t.Errorf("tot.Backends[1] = %+v, want empty build/run", got)
+	}
+
+	other := benchmarks[1]
+	if other.Name != "other" || len(other.Backends) != 1 {
+		t.Errorf("benchmarks[1] = %+v, want name=other with 1 backend", other)
+	}
+}
+
+func TestParseSpecBackendBeforeBenchmark(t *testing.T) {
+	input := `[[benchmark.backend]]
+name = "go"
+`
+	_, err := parseSpec(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("parseSpec accepted [[benchmark.backend]] before any [[benchmark]], want an error")
+	}
+}
+
+func TestParseSpecUnknownKey(t *testing.T) {
+	input := `[[benchmark]]
+name = "tot"
+bogus = "x"
+`
+	_, err := parseSpec(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("parseSpec accepted an unknown benchmark key, want an error")
+	}
+}
+
+func TestParseKV(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{`name = "tot"`, "name", "tot", false},
+		{`name="tot"`, "name", "tot", false},
+		{`build = ""`, "build", "", false},
+		{"malformed line with no equals", "", "", true},
+		{`name = tot`, "", "", true}, // unquoted value
+		{`name = "unterminated`, "", "", true},
+	}
+	for _, tt := range tests {
+		key, value, err := parseKV(tt.line)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseKV(%q) = (%q, %q, nil), want an error", tt.line, key, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseKV(%q) returned error: %v", tt.line, err)
+			continue
+		}
+		if key != tt.wantKey || value != tt.wantValue {
+			t.Errorf("parseKV(%q) = (%q, %q), want (%q, %q)", tt.line, key, value, tt.wantKey, tt.wantValue)
+		}
+	}
+}
+
+func TestParseSpecRealBenchspec(t *testing.T) {
+	// The committed benchspec.toml should parse cleanly end to end.
+	f, err := os.Open("../../benchspec.toml")
+	if err != nil {
+		t.Fatalf("os.Open: %v", err)
+	}
+	defer f.Close()
+
+	benchmarks, err := parseSpec(f)
+	if err != nil {
+		t.Fatalf("parseSpec: %v", err)
+	}
+	if len(benchmarks) == 0 {
+		t.Fatal("got 0 benchmarks from benchspec.toml, want at least 1")
+	}
+}
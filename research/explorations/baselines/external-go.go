@@ -1,8 +1,17 @@
+// external-go.go is the Go reference baseline used to compare
+// ilo-lang's generated code against a hand-written equivalent. It is a
+// thin driver around the bench package: benchmarks are registered by
+// name and main just runs whichever ones were requested on the command
+// line.
 package main
 
 import (
+	"flag"
 	"fmt"
-	"time"
+	"os"
+	"sort"
+
+	"github.com/danieljohnmorris/ilo-lang/research/explorations/baselines/bench"
 )
 
 //go:noinline
@@ -12,22 +21,53 @@ func tot(p, q, r float64) float64 {
 	return s + t
 }
 
+// benchmarks maps a benchmark name to the function it times. New
+// ilo-lang micro-benchmarks should be added here rather than given
+// their own main. Each entry stores its result via bench.StoreFloat64
+// so the compiler can't prove the call is dead and elide it.
+var benchmarks = map[string]func(){
+	"tot": func() { bench.StoreFloat64(tot(10, 20, 30)) },
+}
+
 func main() {
-	n := 10000
-	for i := 0; i < 1000; i++ {
-		tot(float64(i), float64(i+1), float64(i+2))
+	count := flag.Int("count", bench.DefaultOptions.Count, "number of timed repetitions per benchmark")
+	benchTime := flag.Duration("benchtime", bench.DefaultOptions.BenchTime, "target duration of each timed repetition")
+	warmup := flag.Duration("warmup", bench.DefaultOptions.Warmup, "duration to run each benchmark before measurement begins")
+	output := flag.String("output", string(bench.Text), fmt.Sprintf("output format: %s", bench.Formats))
+	flag.Parse()
+
+	opts := bench.Options{
+		Count:     *count,
+		BenchTime: *benchTime,
+		Warmup:    *warmup,
+	}
+
+	names := flag.Args()
+	if len(names) == 0 {
+		names = sortedNames(benchmarks)
 	}
 
-	start := time.Now()
-	var r float64
-	for i := 0; i < n; i++ {
-		r = tot(10, 20, 30)
+	results := make([]bench.Result, 0, len(names))
+	for _, name := range names {
+		fn, ok := benchmarks[name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown benchmark: %s\n", name)
+			os.Exit(1)
+		}
+		results = append(results, bench.Run(name, fn, opts))
 	}
-	elapsed := time.Since(start)
-	per := elapsed.Nanoseconds() / int64(n)
 
-	fmt.Printf("result:     %.0f\n", r)
-	fmt.Printf("iterations: %d\n", n)
-	fmt.Printf("total:      %.2fms\n", float64(elapsed.Nanoseconds())/1e6)
-	fmt.Printf("per call:   %dns\n", per)
+	if err := bench.Write(os.Stdout, bench.Format(*output), results); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func sortedNames(m map[string]func()) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }